@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/viper"
 
 	_ "github.com/golang/glog"
+	"k8s.io/klog"
 )
 
 var Version string
@@ -39,9 +40,15 @@ var (
 	region     = "default"
 	endpoint   = "unix://csi/csi.sock"
 	kubeconfig = ""
+	procfs     = "/proc"
+
+	runControllerService = false
+	runNodeService       = false
+	runDiscovery         = false
+
+	// deprecated, kept for backwards compatibility
 	controller = false
 	driver     = false
-	procfs     = "/proc"
 )
 
 var driverCmd = &cobra.Command{
@@ -56,8 +63,18 @@ For more information, use '%s man [sched | examples | ...]'
 `, os.Args[0]),
 	SilenceUsage: true,
 	RunE: func(c *cobra.Command, args []string) error {
-		if !controller && !driver {
-			return fmt.Errorf("either --controller or --driver should be set")
+		if controller {
+			klog.Warningf("--controller is deprecated, use --run-controller-service instead")
+			runControllerService = true
+		}
+		if driver {
+			klog.Warningf("--driver is deprecated, use --run-node-service and --run-discovery instead")
+			runNodeService = true
+			runDiscovery = true
+		}
+
+		if !runControllerService && !runNodeService && !runDiscovery {
+			return fmt.Errorf("atleast one of --run-controller-service, --run-node-service or --run-discovery should be set")
 		}
 
 		return run(c.Context(), args)
@@ -85,8 +102,13 @@ func init() {
 	driverCmd.Flags().StringVarP(&zone, "zone", "", zone, "identity of the zone in which this direct-csi is running")
 	driverCmd.Flags().StringVarP(&region, "region", "", region, "identity of the region in which this direct-csi is running")
 	driverCmd.Flags().StringVarP(&procfs, "procfs", "", procfs, "path to host /proc for accessing mount information")
-	driverCmd.Flags().BoolVarP(&controller, "controller", "", controller, "running in controller mode")
-	driverCmd.Flags().BoolVarP(&driver, "driver", "", driver, "run in driver mode")
+	driverCmd.Flags().BoolVarP(&runControllerService, "run-controller-service", "", runControllerService, "run the CSI controller service")
+	driverCmd.Flags().BoolVarP(&runNodeService, "run-node-service", "", runNodeService, "run the CSI node service")
+	driverCmd.Flags().BoolVarP(&runDiscovery, "run-discovery", "", runDiscovery, "run the drive discovery loop")
+
+	// deprecated flags, mapped onto the flags above at RunE time
+	driverCmd.Flags().BoolVarP(&controller, "controller", "", controller, "(deprecated, use --run-controller-service) running in controller mode")
+	driverCmd.Flags().BoolVarP(&driver, "driver", "", driver, "(deprecated, use --run-node-service and --run-discovery) run in driver mode")
 
 	driverCmd.PersistentFlags().MarkHidden("alsologtostderr")
 	driverCmd.PersistentFlags().MarkHidden("log_backtrace_at")
@@ -0,0 +1,190 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/minio/direct-csi/pkg/clientset"
+	csicontroller "github.com/minio/direct-csi/pkg/controller"
+	"github.com/minio/direct-csi/pkg/node"
+	"github.com/minio/direct-csi/pkg/node/discovery"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// discoveryInterval is how often runDiscoveryLoop re-syncs this node's
+// drives.
+const discoveryInterval = 30 * time.Second
+
+// run starts whichever combination of the controller service, node service
+// and discovery loop was requested on the command line. Each is independent
+// of the others so a single binary can be run as either the controller
+// Deployment or the node DaemonSet depending on which flags are set.
+func run(ctx context.Context, args []string) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	if runControllerService {
+		g.Go(func() error {
+			return runControllerServer(ctx)
+		})
+	}
+
+	if runNodeService {
+		g.Go(func() error {
+			return runNodeServer(ctx)
+		})
+	}
+
+	if runDiscovery {
+		g.Go(func() error {
+			return runDiscoveryLoop(ctx)
+		})
+	}
+
+	return g.Wait()
+}
+
+// runControllerServer starts the CSI controller gRPC service (CreateVolume,
+// DeleteVolume, ControllerGetCapabilities, ...) on endpoint.
+func runControllerServer(ctx context.Context) error {
+	klog.Infof("starting controller service on %s", endpoint)
+	utils.Init()
+	server := csicontroller.NewControllerServer(identity, utils.GetDirectCSIClient())
+	return listenAndServeCSI(ctx, endpoint, func(s *grpc.Server) {
+		csi.RegisterIdentityServer(s, server)
+		csi.RegisterControllerServer(s, server)
+	})
+}
+
+// runNodeServer starts the CSI node gRPC service (NodeStageVolume,
+// NodePublishVolume, ...) on endpoint.
+func runNodeServer(ctx context.Context) error {
+	klog.Infof("starting node service on %s", endpoint)
+	server := node.NewNodeServerWithMounter(identity, nodeID, &sys.DefaultDriveMounter{})
+	return listenAndServeCSI(ctx, endpoint, func(s *grpc.Server) {
+		csi.RegisterIdentityServer(s, server)
+		csi.RegisterNodeServer(s, server)
+	})
+}
+
+// runDiscoveryLoop periodically re-syncs every DirectCSIDrive already
+// recorded for this node, which re-verifies (and re-establishes, if needed)
+// each drive's mount. Enumerating the host's block devices from scratch
+// (udev/sysfs probing) is not implemented in this repo slice yet, so newly
+// attached drives are not picked up by this loop.
+func runDiscoveryLoop(ctx context.Context) error {
+	klog.Infof("starting discovery loop for node %s", nodeID)
+	utils.Init()
+	directcsiClient := utils.GetDirectCSIClient()
+	disc := discovery.NewDiscovery(directcsiClient)
+
+	ticker := time.NewTicker(discoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := discoverOnce(ctx, disc, directcsiClient); err != nil {
+			klog.Errorf("discovery cycle failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// discoverOnce lists every DirectCSIDrive belonging to this node and syncs
+// each through disc.
+func discoverOnce(ctx context.Context, disc *discovery.Discovery, directcsiClient clientset.Interface) error {
+	driveList, err := directcsiClient.DirectV1beta2().DirectCSIDrives().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range driveList.Items {
+		drive := driveList.Items[i]
+		if drive.Status.NodeName != nodeID {
+			continue
+		}
+		if err := disc.Sync(ctx, &drive); err != nil {
+			klog.Errorf("syncing drive %s: %v", drive.Name, err)
+		}
+	}
+	return nil
+}
+
+// listenAndServeCSI parses endpoint - a unix:// or tcp:// URL, as used
+// throughout the CSI ecosystem - and serves it on a gRPC server configured by
+// register until ctx is cancelled.
+func listenAndServeCSI(ctx context.Context, endpoint string, register func(*grpc.Server)) error {
+	listener, err := newCSIListener(endpoint)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	server := grpc.NewServer()
+	register(server)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Serve(listener) }()
+
+	select {
+	case <-ctx.Done():
+		server.GracefulStop()
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
+
+// newCSIListener binds a net.Listener to endpoint, removing any stale unix
+// socket left behind by a previous, uncleanly terminated run.
+func newCSIListener(endpoint string) (net.Listener, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		addr := u.Host + u.Path
+		if addr == "" {
+			addr = u.Opaque
+		}
+		if err := os.RemoveAll(addr); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("removing stale socket %s: %w", addr, err)
+		}
+		return net.Listen("unix", addr)
+	case "tcp":
+		return net.Listen("tcp", u.Host)
+	default:
+		return nil, fmt.Errorf("unsupported endpoint scheme %q", u.Scheme)
+	}
+}
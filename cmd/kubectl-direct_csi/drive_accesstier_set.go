@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta1"
+	"github.com/minio/direct-csi/pkg/selectors"
 	"github.com/minio/direct-csi/pkg/utils"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -66,7 +67,6 @@ func init() {
 }
 
 func setAccessTier(ctx context.Context, args []string) error {
-	dryRun := viper.GetBool(dryRunFlagName)
 	if !all {
 		if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 {
 			return fmt.Errorf("atleast one of '%s', '%s', '%s' or '%s' should be specified", utils.Bold("--all"), utils.Bold("--drives"), utils.Bold("--nodes"), utils.Bold("--status"))
@@ -77,6 +77,21 @@ func setAccessTier(ctx context.Context, args []string) error {
 		return fmt.Errorf("Invalid input arguments. Please use '%s' for examples to set access-tiers", utils.Bold("--help"))
 	}
 
+	drives, err := selectors.GetValidDriveSelectors(drives)
+	if err != nil {
+		return err
+	}
+	nodes, err := selectors.GetValidNodeSelectors(nodes)
+	if err != nil {
+		return err
+	}
+	status, err := selectors.GetValidStatusSelectors(status)
+	if err != nil {
+		return err
+	}
+
+	dryRun := viper.GetBool(dryRunFlagName)
+
 	accessT, err := utils.ValidateAccessTier(args[0])
 	if err != nil {
 		return err
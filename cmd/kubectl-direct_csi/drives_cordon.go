@@ -0,0 +1,174 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	"github.com/minio/direct-csi/pkg/selectors"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	cordonAccessTier = []string{}
+)
+
+var driveCordon = &cobra.Command{
+	Use:   "cordon",
+	Short: "cordon DirectCSI drive(s)",
+	Long:  "",
+	Example: `
+# Cordon all the DirectCSI drives
+$ kubectl direct-csi drives cordon --all
+
+# Cordon all nvme drives in all nodes
+$ kubectl direct-csi drives cordon --drives=/dev/nvme*
+
+# Cordon specific drives from a particular node
+$ kubectl direct-csi drives cordon --nodes=directcsi-1 --drives=/dev/xvd{a,b,c}
+
+# Cordon drives that are in 'ready' state
+$ kubectl direct-csi drives cordon --status=ready
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		return cordonDrives(c.Context(), args, true)
+	},
+	Aliases: []string{},
+}
+
+var driveUncordon = &cobra.Command{
+	Use:   "uncordon",
+	Short: "uncordon DirectCSI drive(s)",
+	Long:  "",
+	Example: `
+# Uncordon all the DirectCSI drives
+$ kubectl direct-csi drives uncordon --all
+
+# Uncordon drives from a particular node
+$ kubectl direct-csi drives uncordon --nodes=directcsi-1
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		return cordonDrives(c.Context(), args, false)
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	driveCordon.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "glob selector for drive paths")
+	driveCordon.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "glob selector for node names")
+	driveCordon.PersistentFlags().BoolVarP(&all, "all", "a", all, "cordon all available drives")
+	driveCordon.PersistentFlags().StringSliceVarP(&status, "status", "s", status, "glob prefix match for drive status")
+	driveCordon.PersistentFlags().StringSliceVarP(&cordonAccessTier, "access-tier", "", cordonAccessTier, "glob prefix match for access-tier")
+
+	driveUncordon.PersistentFlags().StringSliceVarP(&drives, "drives", "d", drives, "glob selector for drive paths")
+	driveUncordon.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "glob selector for node names")
+	driveUncordon.PersistentFlags().BoolVarP(&all, "all", "a", all, "uncordon all available drives")
+	driveUncordon.PersistentFlags().StringSliceVarP(&status, "status", "s", status, "glob prefix match for drive status")
+	driveUncordon.PersistentFlags().StringSliceVarP(&cordonAccessTier, "access-tier", "", cordonAccessTier, "glob prefix match for access-tier")
+}
+
+// matchAccessTierGlob matches case-insensitively to mirror
+// selectors.GetValidAccessTierSelectors, which accepts e.g.
+// "--access-tier=cold" against the canonical value "Cold".
+func matchAccessTierGlob(patterns []string, accessTier directcsi.AccessTier) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(strings.ToLower(p), strings.ToLower(string(accessTier))); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func cordonDrives(ctx context.Context, args []string, cordon bool) error {
+	if !all {
+		if len(drives) == 0 && len(nodes) == 0 && len(status) == 0 && len(cordonAccessTier) == 0 {
+			return fmt.Errorf("atleast one of '%s', '%s', '%s', '%s' or '%s' should be specified", utils.Bold("--all"), utils.Bold("--drives"), utils.Bold("--nodes"), utils.Bold("--status"), utils.Bold("--access-tier"))
+		}
+	}
+
+	drives, err := selectors.GetValidDriveSelectors(drives)
+	if err != nil {
+		return err
+	}
+	nodes, err := selectors.GetValidNodeSelectors(nodes)
+	if err != nil {
+		return err
+	}
+	status, err := selectors.GetValidStatusSelectors(status)
+	if err != nil {
+		return err
+	}
+	cordonAccessTier, err := selectors.GetValidAccessTierSelectors(cordonAccessTier)
+	if err != nil {
+		return err
+	}
+
+	dryRun := viper.GetBool(dryRunFlagName)
+
+	utils.Init()
+
+	directClient := utils.GetDirectCSIClient()
+	driveList, err := directClient.DirectCSIDrives().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	if len(driveList.Items) == 0 {
+		glog.Errorf("No resource of %s found\n", bold("DirectCSIDrive"))
+		return fmt.Errorf("No resources found")
+	}
+
+	filterDrives := []directcsi.DirectCSIDrive{}
+	for _, d := range driveList.Items {
+		if d.MatchGlob(nodes, drives, status) && matchAccessTierGlob(cordonAccessTier, d.Status.AccessTier) {
+			filterDrives = append(filterDrives, d)
+		}
+	}
+
+	for _, d := range filterDrives {
+		if d.Status.Cordoned == cordon {
+			continue
+		}
+		d.Status.Cordoned = cordon
+		if dryRun {
+			if err := utils.LogYAML(d); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := directClient.DirectCSIDrives().Update(ctx, &d, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	clientv1beta2 "github.com/minio/direct-csi/pkg/clientset/v1beta2"
+	"github.com/minio/direct-csi/pkg/node/discovery"
+	"github.com/minio/direct-csi/pkg/selectors"
+	"github.com/minio/direct-csi/pkg/sys"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+var driveMove = &cobra.Command{
+	Use:   "move <src> <dest>",
+	Short: "move volume references from a failed/draining drive to a healthy one",
+	Long: `
+Transfers ownership of every DirectCSIVolume hosted on a cordoned or
+unavailable source drive to a healthy destination drive on the same node.
+No data is copied - this is intended for post-failure recovery where the
+destination already holds equivalent content (e.g. restored from backup or
+a replaced disk with the same data).`,
+	Example: `
+# Move all volume references from drive A to drive B on the same node
+$ kubectl direct-csi drives move /dev/sdb /dev/sdc --nodes=directcsi-1
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(c *cobra.Command, args []string) error {
+		return moveDrive(c.Context(), args[0], args[1])
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	driveMove.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", nodes, "glob selector for node names")
+}
+
+func moveDrive(ctx context.Context, src, dest string) error {
+	if len(nodes) == 0 {
+		return fmt.Errorf("'%s' should be specified to disambiguate the node the drives belong to", utils.Bold("--nodes"))
+	}
+
+	nodes, err := selectors.GetValidNodeSelectors(nodes)
+	if err != nil {
+		return err
+	}
+
+	utils.Init()
+
+	directClient := utils.GetDirectCSIClient()
+
+	driveList, err := directClient.DirectCSIDrives().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	srcDrive, err := findDriveByPath(driveList.Items, nodes, src)
+	if err != nil {
+		return err
+	}
+	destDrive, err := findDriveByPath(driveList.Items, nodes, dest)
+	if err != nil {
+		return err
+	}
+
+	if srcDrive.Status.NodeName != destDrive.Status.NodeName {
+		return fmt.Errorf("source drive %s and destination drive %s must be on the same node, found %s and %s", utils.Bold(src), utils.Bold(dest), srcDrive.Status.NodeName, destDrive.Status.NodeName)
+	}
+
+	if srcDrive.Status.DriveStatus != directcsi.DriveStatusUnavailable && !srcDrive.Status.Cordoned {
+		return fmt.Errorf("source drive %s must be cordoned or unavailable before it can be moved", utils.Bold(src))
+	}
+	if srcDrive.Status.Mountpoint != "" {
+		return fmt.Errorf("source drive %s holds an active mount at %s and cannot be moved", utils.Bold(src), srcDrive.Status.Mountpoint)
+	}
+
+	volumeList, err := directClient.DirectCSIVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var toMove []directcsi.DirectCSIVolume
+	for _, v := range volumeList.Items {
+		if v.Status.Drive == srcDrive.Name {
+			toMove = append(toMove, v)
+		}
+	}
+
+	var transferred uint64
+	for _, v := range toMove {
+		transferred += v.Status.TotalCapacity
+	}
+	if transferred > destDrive.Status.FreeCapacity {
+		return fmt.Errorf("destination drive %s does not have enough free capacity (%d bytes required)", utils.Bold(dest), transferred)
+	}
+
+	eventRecorder := utils.GetEventRecorder()
+
+	for _, v := range toMove {
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			volume, err := directClient.DirectCSIVolumes().Get(ctx, v.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			volume.Status.Drive = destDrive.Name
+			_, err = directClient.DirectCSIVolumes().Update(ctx, volume, metav1.UpdateOptions{})
+			return err
+		}); err != nil {
+			return err
+		}
+
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			drive, err := directClient.DirectCSIDrives().Get(ctx, srcDrive.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			drive.Finalizers = removeFinalizer(drive.Finalizers, driveFinalizerPrefix+v.Name)
+			_, err = directClient.DirectCSIDrives().Update(ctx, drive, metav1.UpdateOptions{})
+			return err
+		}); err != nil {
+			return err
+		}
+
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			drive, err := directClient.DirectCSIDrives().Get(ctx, destDrive.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			drive.Finalizers = append(drive.Finalizers, driveFinalizerPrefix+v.Name)
+			drive.Status.DriveStatus = directcsi.DriveStatusInUse
+			_, err = directClient.DirectCSIDrives().Update(ctx, drive, metav1.UpdateOptions{})
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The destination keeps its own FilesystemUUID - no data was copied - so
+	// remount it under that UUID now rather than waiting for the node's next
+	// discovery cycle to notice the ownership change.
+	if err := remountDestination(ctx, directClient, destDrive.Name); err != nil {
+		return fmt.Errorf("remounting destination drive %s: %w", utils.Bold(dest), err)
+	}
+
+	eventRecorder.Event(srcDrive, corev1.EventTypeNormal, "DriveMoveOut", fmt.Sprintf("moved %d volume(s) to %s", len(toMove), destDrive.Name))
+	eventRecorder.Event(destDrive, corev1.EventTypeNormal, "DriveMoveIn", fmt.Sprintf("received %d volume(s) from %s", len(toMove), srcDrive.Name))
+
+	glog.Infof("moved %d volume(s) from %s to %s\n", len(toMove), src, dest)
+	return nil
+}
+
+func findDriveByPath(drives []directcsi.DirectCSIDrive, nodeGlob []string, path string) (*directcsi.DirectCSIDrive, error) {
+	for i := range drives {
+		d := drives[i]
+		if d.Status.Path == path && d.MatchGlob(nodeGlob, nil, nil) {
+			return &d, nil
+		}
+	}
+	return nil, fmt.Errorf("drive %s not found", utils.Bold(path))
+}
+
+// remountDestination runs the same mount logic discovery uses against the
+// destination drive, under its own FilesystemUUID, and persists the
+// resulting Status.Mountpoint.
+func remountDestination(ctx context.Context, directClient clientv1beta2.DirectV1beta2Interface, driveName string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		driveClient := directClient.DirectCSIDrives()
+		drive, err := driveClient.Get(ctx, driveName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		// This CLI has no view of the node's live mount table, unlike the
+		// discovery loop running on that node - pass no known mounts so
+		// VerifyDriveMount always issues the mount. If mount(8) fails, e.g.
+		// because the destination is already mounted there, the error is
+		// returned and the drive is not updated.
+		if err := discovery.VerifyDriveMount(&sys.DefaultDriveMounter{}, nil, drive); err != nil {
+			return err
+		}
+		_, err = driveClient.Update(ctx, drive, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func removeFinalizer(finalizers []string, target string) []string {
+	filtered := finalizers[:0]
+	for _, f := range finalizers {
+		if f != target {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+const driveFinalizerPrefix = "direct.csi.min.io/volume-"
@@ -0,0 +1,109 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/minio/direct-csi/pkg/installer"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	installImage   = "direct-csi"
+	declarative    = false
+	installOutput  = "yaml"
+	installWithPSP = false
+)
+
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: "install direct-csi in the Kubernetes cluster",
+	Long:  "",
+	Example: `
+# Install direct-csi in the cluster, applying resources via the API server
+$ kubectl direct-csi install
+
+# Emit the full install manifest to stdout instead of applying it
+$ kubectl direct-csi install --declarative -o yaml > direct-csi-install.yaml
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		if declarative {
+			return runDeclarativeInstall()
+		}
+		return applyInstall(c.Context())
+	},
+	Aliases: []string{},
+}
+
+func init() {
+	installCmd.PersistentFlags().StringVarP(&installImage, "image", "", installImage, "direct-csi image to install")
+	installCmd.PersistentFlags().BoolVarP(&installWithPSP, "psp", "", installWithPSP, "also install a PodSecurityPolicy")
+	installCmd.PersistentFlags().BoolVarP(&declarative, "declarative", "", declarative, "emit the install manifest to stdout instead of applying it")
+	installCmd.PersistentFlags().StringVarP(&installOutput, "output", "o", installOutput, "output format for --declarative (only 'yaml' is supported today)")
+}
+
+// installArgsFromFlags builds an installer.Args from the same viper-bound
+// flags driverCmd uses, so the generated manifest always matches what a
+// live `install` would have applied.
+func installArgsFromFlags() *installer.Args {
+	args := installer.DefaultArgs()
+	if v := viper.GetString("identity"); v != "" {
+		args.Identity = v
+	}
+	args.Image = installImage
+	if v := viper.GetString("rack"); v != "" {
+		args.Rack = v
+	}
+	if v := viper.GetString("zone"); v != "" {
+		args.Zone = v
+	}
+	if v := viper.GetString("region"); v != "" {
+		args.Region = v
+	}
+	args.WithPSP = installWithPSP
+	return args
+}
+
+// runDeclarativeInstall handles `install --declarative`, short-circuiting
+// the dynamic-client based apply path used otherwise.
+func runDeclarativeInstall() error {
+	if installOutput != "yaml" {
+		return fmt.Errorf("unsupported --output %q, only 'yaml' is supported", installOutput)
+	}
+	return installer.WriteYAML(os.Stdout, installArgsFromFlags())
+}
+
+// applyInstall applies the same manifest set via the dynamic client, for
+// users who don't want the GitOps-style declarative flow.
+func applyInstall(ctx context.Context) error {
+	utils.Init()
+	dynamicClient := utils.GetDynamicClient()
+	for _, obj := range installer.Manifests(installArgsFromFlags(), installWithPSP) {
+		if err := utils.ApplyObject(ctx, dynamicClient, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ * This file is part of MinIO Direct CSI
+ * Copyright (C) 2021, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/minio/direct-csi/pkg/installer"
+	"github.com/minio/direct-csi/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "diff a declaratively generated manifest against the live cluster",
+	Long: `
+Builds the same manifest set as 'install --declarative' and compares each
+resource against what is currently applied in the cluster, printing a
+per-resource change plan. Nothing is applied; this is meant to drive a
+GitOps workflow where the generated manifest is the source of truth.`,
+	Example: `
+$ kubectl direct-csi migrate
+`,
+	RunE: func(c *cobra.Command, args []string) error {
+		return runMigrate(c.Context())
+	},
+	Aliases: []string{},
+}
+
+func runMigrate(ctx context.Context) error {
+	utils.Init()
+	dynamicClient := utils.GetDynamicClient()
+
+	plan, err := diffManifests(ctx, dynamicClient, installer.Manifests(installArgsFromFlags(), installWithPSP))
+	if err != nil {
+		return err
+	}
+
+	upToDate := true
+	for _, change := range plan {
+		if !strings.HasPrefix(change, "=") {
+			upToDate = false
+			break
+		}
+	}
+	if upToDate {
+		fmt.Println("cluster already matches the generated manifest")
+		return nil
+	}
+
+	for _, change := range plan {
+		fmt.Println(change)
+	}
+	return nil
+}
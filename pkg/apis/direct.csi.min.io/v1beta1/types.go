@@ -0,0 +1,109 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1beta1
+
+import (
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriveStatus is the lifecycle state of a DirectCSIDrive.
+type DriveStatus string
+
+// Valid DriveStatus values.
+const (
+	DriveStatusUnidentified DriveStatus = "Unidentified"
+	DriveStatusAvailable    DriveStatus = "Available"
+	DriveStatusReady        DriveStatus = "Ready"
+	DriveStatusInUse        DriveStatus = "InUse"
+	DriveStatusUnavailable  DriveStatus = "Unavailable"
+)
+
+// AccessTier classifies a drive's performance tier for scheduling.
+type AccessTier string
+
+// Valid AccessTier values.
+const (
+	AccessTierHot     AccessTier = "Hot"
+	AccessTierWarm    AccessTier = "Warm"
+	AccessTierCold    AccessTier = "Cold"
+	AccessTierUnknown AccessTier = ""
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectCSIDrive is the legacy (v1beta1) representation of a drive, kept
+// only so existing objects can be read and upgraded by onSyncLegacyFn.
+type DirectCSIDrive struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DirectCSIDriveStatus `json:"status,omitempty"`
+}
+
+// DirectCSIDriveStatus is the legacy (v1beta1) drive status.
+type DirectCSIDriveStatus struct {
+	NodeName string `json:"nodeName,omitempty"`
+	Path     string `json:"path,omitempty"`
+
+	DriveStatus DriveStatus `json:"driveStatus,omitempty"`
+	AccessTier  AccessTier  `json:"accessTier,omitempty"`
+}
+
+// MatchGlob reports whether this drive matches every supplied glob list.
+// An empty list for a given dimension matches everything.
+func (d DirectCSIDrive) MatchGlob(nodeGlobs, driveGlobs, statusGlobs []string) bool {
+	matchesAny := func(globs []string, value string) bool {
+		if len(globs) == 0 {
+			return true
+		}
+		for _, g := range globs {
+			if ok, _ := filepath.Match(g, value); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	// DriveStatus globs are matched case-insensitively to mirror
+	// selectors.GetValidStatusSelectors, which accepts e.g. "--status=ready"
+	// against the canonical value "Ready".
+	matchesAnyStatus := func(globs []string, value string) bool {
+		if len(globs) == 0 {
+			return true
+		}
+		lowered := make([]string, len(globs))
+		for i, g := range globs {
+			lowered[i] = strings.ToLower(g)
+		}
+		return matchesAny(lowered, strings.ToLower(value))
+	}
+
+	return matchesAny(nodeGlobs, d.Status.NodeName) &&
+		matchesAny(driveGlobs, d.Status.Path) &&
+		matchesAnyStatus(statusGlobs, string(d.Status.DriveStatus))
+}
+
+// DirectCSIDriveList is a list of DirectCSIDrive.
+type DirectCSIDriveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DirectCSIDrive `json:"items"`
+}
@@ -0,0 +1,57 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package v1beta2 is the current version of the direct.csi.min.io API group.
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Group and Version identify this API group/version, e.g. for building
+// metav1.TypeMeta values when talking to the API server directly.
+const (
+	Group   = "direct.csi.min.io"
+	Version = "v1beta2"
+)
+
+// SchemeGroupVersion is the GroupVersion this package registers types for.
+var SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+// Resource takes an unqualified resource and returns a Group-qualified
+// GroupResource.
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+// SchemeBuilder collects the functions that add types to an API scheme.
+var (
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme   = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&DirectCSIDrive{},
+		&DirectCSIDriveList{},
+		&DirectCSIVolume{},
+		&DirectCSIVolumeList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
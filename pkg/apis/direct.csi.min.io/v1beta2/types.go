@@ -0,0 +1,197 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1beta2
+
+import (
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriveStatus is the lifecycle state of a DirectCSIDrive.
+type DriveStatus string
+
+// Valid DriveStatus values.
+const (
+	DriveStatusUnidentified DriveStatus = "Unidentified"
+	DriveStatusAvailable    DriveStatus = "Available"
+	DriveStatusReady        DriveStatus = "Ready"
+	DriveStatusInUse        DriveStatus = "InUse"
+	DriveStatusUnavailable  DriveStatus = "Unavailable"
+	DriveStatusTerminating  DriveStatus = "Terminating"
+	DriveStatusReleased     DriveStatus = "Released"
+)
+
+// AccessTier classifies a drive's performance tier for scheduling.
+type AccessTier string
+
+// Valid AccessTier values.
+const (
+	AccessTierHot     AccessTier = "Hot"
+	AccessTierWarm    AccessTier = "Warm"
+	AccessTierCold    AccessTier = "Cold"
+	AccessTierUnknown AccessTier = ""
+)
+
+// DirectCSIDriveCondition enumerates the condition types reported in
+// DirectCSIDriveStatus.Conditions.
+type DirectCSIDriveCondition string
+
+// Condition types reported on a DirectCSIDrive.
+const (
+	DirectCSIDriveConditionMounted     DirectCSIDriveCondition = "Mounted"
+	DirectCSIDriveConditionFormatted   DirectCSIDriveCondition = "Formatted"
+	DirectCSIDriveConditionOwned       DirectCSIDriveCondition = "Owned"
+	DirectCSIDriveConditionSchedulable DirectCSIDriveCondition = "Schedulable"
+)
+
+// DirectCSIDriveReason enumerates the reasons reported alongside a
+// DirectCSIDriveCondition.
+type DirectCSIDriveReason string
+
+// Condition reasons reported on a DirectCSIDrive.
+const (
+	DirectCSIDriveReasonInitialized DirectCSIDriveReason = "Initialized"
+	DirectCSIDriveReasonAdded       DirectCSIDriveReason = "Added"
+	DirectCSIDriveReasonCordoned    DirectCSIDriveReason = "Cordoned"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DirectCSIDrive represents a single block device discovered and managed by
+// direct-csi.
+type DirectCSIDrive struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DirectCSIDriveStatus `json:"status,omitempty"`
+}
+
+// DirectCSIDriveStatus is the observed and operator-managed state of a drive.
+type DirectCSIDriveStatus struct {
+	NodeName      string `json:"nodeName,omitempty"`
+	Path          string `json:"path,omitempty"`
+	CurrentPath   string `json:"currentPath,omitempty"`
+	RootPartition string `json:"rootPartition,omitempty"`
+	PartitionNum  int    `json:"partitionNum,omitempty"`
+
+	Filesystem   string   `json:"filesystem,omitempty"`
+	Mountpoint   string   `json:"mountpoint,omitempty"`
+	MountOptions []string `json:"mountOptions,omitempty"`
+
+	ModelNumber       string `json:"modelNumber,omitempty"`
+	SerialNumber      string `json:"serialNumber,omitempty"`
+	FilesystemUUID    string `json:"filesystemUUID,omitempty"`
+	PartitionUUID     string `json:"partitionUUID,omitempty"`
+	MajorNumber       uint32 `json:"majorNumber,omitempty"`
+	MinorNumber       uint32 `json:"minorNumber,omitempty"`
+	PhysicalBlockSize int    `json:"physicalBlockSize,omitempty"`
+	LogicalBlockSize  int    `json:"logicalBlockSize,omitempty"`
+
+	TotalCapacity     uint64 `json:"totalCapacity,omitempty"`
+	AllocatedCapacity uint64 `json:"allocatedCapacity,omitempty"`
+	FreeCapacity      uint64 `json:"freeCapacity,omitempty"`
+
+	DriveStatus DriveStatus `json:"driveStatus,omitempty"`
+	AccessTier  AccessTier  `json:"accessTier,omitempty"`
+
+	// Cordoned marks the drive as ineligible for new volume scheduling.
+	// Existing InUse volumes on a cordoned drive keep functioning; only
+	// new PVC allocations are skipped. Discovery must never clear this
+	// on its own - it is operator/controller managed.
+	Cordoned bool `json:"cordoned,omitempty"`
+
+	// Labels records controller-managed key/value pairs against this
+	// drive, e.g. one entry per direct.csi.min.io/volume-claim-id value
+	// reserved by CreateVolume for a volume provisioned here, for
+	// anti-affinity scheduling decisions. DeleteVolume does not remove
+	// these entries.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// MatchGlob reports whether this drive matches every supplied glob list.
+// An empty list for a given dimension matches everything.
+func (d DirectCSIDrive) MatchGlob(nodeGlobs, driveGlobs, statusGlobs []string) bool {
+	matchesAny := func(globs []string, value string) bool {
+		if len(globs) == 0 {
+			return true
+		}
+		for _, g := range globs {
+			if ok, _ := filepath.Match(g, value); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	// DriveStatus globs are matched case-insensitively to mirror
+	// selectors.GetValidStatusSelectors, which accepts e.g. "--status=ready"
+	// against the canonical value "Ready".
+	matchesAnyStatus := func(globs []string, value string) bool {
+		if len(globs) == 0 {
+			return true
+		}
+		lowered := make([]string, len(globs))
+		for i, g := range globs {
+			lowered[i] = strings.ToLower(g)
+		}
+		return matchesAny(lowered, strings.ToLower(value))
+	}
+
+	return matchesAny(nodeGlobs, d.Status.NodeName) &&
+		matchesAny(driveGlobs, d.Status.Path) &&
+		matchesAnyStatus(statusGlobs, string(d.Status.DriveStatus))
+}
+
+// DirectCSIDriveList is a list of DirectCSIDrive.
+type DirectCSIDriveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DirectCSIDrive `json:"items"`
+}
+
+// DirectCSIVolume represents a single provisioned volume backed by a drive.
+type DirectCSIVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DirectCSIVolumeStatus `json:"status,omitempty"`
+}
+
+// DirectCSIVolumeStatus is the observed state of a provisioned volume.
+type DirectCSIVolumeStatus struct {
+	// Drive is the name of the DirectCSIDrive object this volume is
+	// currently hosted on.
+	Drive string `json:"drive,omitempty"`
+
+	NodeName      string `json:"nodeName,omitempty"`
+	TotalCapacity uint64 `json:"totalCapacity,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// DirectCSIVolumeList is a list of DirectCSIVolume.
+type DirectCSIVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DirectCSIVolume `json:"items"`
+}
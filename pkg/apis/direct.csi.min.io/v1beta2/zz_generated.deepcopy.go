@@ -0,0 +1,154 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// This file would normally be produced by controller-gen/deepcopy-gen; it is
+// hand-written here since this repo slice has no generator wired up yet.
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies every field of d into out.
+func (d *DirectCSIDrive) DeepCopyInto(out *DirectCSIDrive) {
+	*out = *d
+	out.TypeMeta = d.TypeMeta
+	d.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	d.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a deep copy of d.
+func (d *DirectCSIDrive) DeepCopy() *DirectCSIDrive {
+	if d == nil {
+		return nil
+	}
+	out := new(DirectCSIDrive)
+	d.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (d *DirectCSIDrive) DeepCopyObject() runtime.Object {
+	return d.DeepCopy()
+}
+
+// DeepCopyInto copies every field of s into out.
+func (s *DirectCSIDriveStatus) DeepCopyInto(out *DirectCSIDriveStatus) {
+	*out = *s
+	if s.MountOptions != nil {
+		out.MountOptions = append([]string(nil), s.MountOptions...)
+	}
+	if s.Labels != nil {
+		out.Labels = make(map[string]string, len(s.Labels))
+		for k, v := range s.Labels {
+			out.Labels[k] = v
+		}
+	}
+	if s.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(s.Conditions))
+		copy(out.Conditions, s.Conditions)
+	}
+}
+
+// DeepCopyInto copies every element of l into out.
+func (l *DirectCSIDriveList) DeepCopyInto(out *DirectCSIDriveList) {
+	*out = *l
+	out.TypeMeta = l.TypeMeta
+	out.ListMeta = l.ListMeta
+	if l.Items != nil {
+		out.Items = make([]DirectCSIDrive, len(l.Items))
+		for i := range l.Items {
+			l.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of l.
+func (l *DirectCSIDriveList) DeepCopy() *DirectCSIDriveList {
+	if l == nil {
+		return nil
+	}
+	out := new(DirectCSIDriveList)
+	l.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *DirectCSIDriveList) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
+
+// DeepCopyInto copies every field of v into out.
+func (v *DirectCSIVolume) DeepCopyInto(out *DirectCSIVolume) {
+	*out = *v
+	out.TypeMeta = v.TypeMeta
+	v.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	v.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a deep copy of v.
+func (v *DirectCSIVolume) DeepCopy() *DirectCSIVolume {
+	if v == nil {
+		return nil
+	}
+	out := new(DirectCSIVolume)
+	v.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (v *DirectCSIVolume) DeepCopyObject() runtime.Object {
+	return v.DeepCopy()
+}
+
+// DeepCopyInto copies every field of s into out.
+func (s *DirectCSIVolumeStatus) DeepCopyInto(out *DirectCSIVolumeStatus) {
+	*out = *s
+	if s.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(s.Conditions))
+		copy(out.Conditions, s.Conditions)
+	}
+}
+
+// DeepCopyInto copies every element of l into out.
+func (l *DirectCSIVolumeList) DeepCopyInto(out *DirectCSIVolumeList) {
+	*out = *l
+	out.TypeMeta = l.TypeMeta
+	out.ListMeta = l.ListMeta
+	if l.Items != nil {
+		out.Items = make([]DirectCSIVolume, len(l.Items))
+		for i := range l.Items {
+			l.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of l.
+func (l *DirectCSIVolumeList) DeepCopy() *DirectCSIVolumeList {
+	if l == nil {
+		return nil
+	}
+	out := new(DirectCSIVolumeList)
+	l.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *DirectCSIVolumeList) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
@@ -0,0 +1,32 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package clientset is the typed client for the direct.csi.min.io API
+// group, covering both the legacy v1beta1 resources and the current
+// v1beta2 ones.
+package clientset
+
+import (
+	v1beta1 "github.com/minio/direct-csi/pkg/clientset/v1beta1"
+	v1beta2 "github.com/minio/direct-csi/pkg/clientset/v1beta2"
+)
+
+// Interface is implemented by both the real (REST-backed) clientset and the
+// fake, in-memory one used in tests.
+type Interface interface {
+	DirectV1beta1() v1beta1.DirectV1beta1Interface
+	DirectV1beta2() v1beta2.DirectV1beta2Interface
+}
@@ -0,0 +1,234 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package fake is an in-memory implementation of clientset.Interface used
+// by the csi-sanity harness (test/e2e) and by unit tests, so neither needs
+// a live API server.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	directcsiv1beta1 "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta1"
+	directcsiv1beta2 "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	"github.com/minio/direct-csi/pkg/clientset"
+	clientv1beta1 "github.com/minio/direct-csi/pkg/clientset/v1beta1"
+	clientv1beta2 "github.com/minio/direct-csi/pkg/clientset/v1beta2"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Clientset is the fake, in-memory clientset.Interface.
+type Clientset struct {
+	mu      sync.Mutex
+	drives  map[string]*directcsiv1beta2.DirectCSIDrive
+	volumes map[string]*directcsiv1beta2.DirectCSIVolume
+}
+
+var _ clientset.Interface = (*Clientset)(nil)
+
+// NewSimpleClientset returns a fake Clientset seeded with the given
+// v1beta2 objects (*DirectCSIDrive / *DirectCSIVolume).
+func NewSimpleClientset(objects ...interface{}) *Clientset {
+	cs := &Clientset{
+		drives:  map[string]*directcsiv1beta2.DirectCSIDrive{},
+		volumes: map[string]*directcsiv1beta2.DirectCSIVolume{},
+	}
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *directcsiv1beta2.DirectCSIDrive:
+			cs.drives[o.Name] = o.DeepCopy()
+		case *directcsiv1beta2.DirectCSIVolume:
+			cs.volumes[o.Name] = o.DeepCopy()
+		}
+	}
+	return cs
+}
+
+// DirectV1beta2 implements clientset.Interface.
+func (c *Clientset) DirectV1beta2() clientv1beta2.DirectV1beta2Interface {
+	return &fakeV1beta2{cs: c}
+}
+
+// DirectV1beta1 implements clientset.Interface. The legacy version is
+// backed by the same store: reads downconvert the stored v1beta2 drives, and
+// Update writes back only DriveStatus and AccessTier.
+func (c *Clientset) DirectV1beta1() clientv1beta1.DirectV1beta1Interface {
+	return &fakeV1beta1{cs: c}
+}
+
+func notFound(resource, name string) error {
+	return errors.NewNotFound(schema.GroupResource{Group: directcsiv1beta2.Group, Resource: resource}, name)
+}
+
+type fakeV1beta2 struct{ cs *Clientset }
+
+func (f *fakeV1beta2) DirectCSIDrives() clientv1beta2.DirectCSIDriveInterface  { return fakeDrives{f.cs} }
+func (f *fakeV1beta2) DirectCSIVolumes() clientv1beta2.DirectCSIVolumeInterface { return fakeVolumes{f.cs} }
+
+type fakeDrives struct{ cs *Clientset }
+
+func (f fakeDrives) Get(_ context.Context, name string, _ metav1.GetOptions) (*directcsiv1beta2.DirectCSIDrive, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	d, ok := f.cs.drives[name]
+	if !ok {
+		return nil, notFound("directcsidrives", name)
+	}
+	return d.DeepCopy(), nil
+}
+
+func (f fakeDrives) List(_ context.Context, _ metav1.ListOptions) (*directcsiv1beta2.DirectCSIDriveList, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	list := &directcsiv1beta2.DirectCSIDriveList{}
+	for _, d := range f.cs.drives {
+		list.Items = append(list.Items, *d.DeepCopy())
+	}
+	return list, nil
+}
+
+func (f fakeDrives) Create(_ context.Context, drive *directcsiv1beta2.DirectCSIDrive, _ metav1.CreateOptions) (*directcsiv1beta2.DirectCSIDrive, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	f.cs.drives[drive.Name] = drive.DeepCopy()
+	return drive.DeepCopy(), nil
+}
+
+func (f fakeDrives) Update(_ context.Context, drive *directcsiv1beta2.DirectCSIDrive, _ metav1.UpdateOptions) (*directcsiv1beta2.DirectCSIDrive, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	if _, ok := f.cs.drives[drive.Name]; !ok {
+		return nil, notFound("directcsidrives", drive.Name)
+	}
+	f.cs.drives[drive.Name] = drive.DeepCopy()
+	return drive.DeepCopy(), nil
+}
+
+func (f fakeDrives) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	if _, ok := f.cs.drives[name]; !ok {
+		return notFound("directcsidrives", name)
+	}
+	delete(f.cs.drives, name)
+	return nil
+}
+
+type fakeVolumes struct{ cs *Clientset }
+
+func (f fakeVolumes) Get(_ context.Context, name string, _ metav1.GetOptions) (*directcsiv1beta2.DirectCSIVolume, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	v, ok := f.cs.volumes[name]
+	if !ok {
+		return nil, notFound("directcsivolumes", name)
+	}
+	return v.DeepCopy(), nil
+}
+
+func (f fakeVolumes) List(_ context.Context, _ metav1.ListOptions) (*directcsiv1beta2.DirectCSIVolumeList, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	list := &directcsiv1beta2.DirectCSIVolumeList{}
+	for _, v := range f.cs.volumes {
+		list.Items = append(list.Items, *v.DeepCopy())
+	}
+	return list, nil
+}
+
+func (f fakeVolumes) Create(_ context.Context, volume *directcsiv1beta2.DirectCSIVolume, _ metav1.CreateOptions) (*directcsiv1beta2.DirectCSIVolume, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	f.cs.volumes[volume.Name] = volume.DeepCopy()
+	return volume.DeepCopy(), nil
+}
+
+func (f fakeVolumes) Update(_ context.Context, volume *directcsiv1beta2.DirectCSIVolume, _ metav1.UpdateOptions) (*directcsiv1beta2.DirectCSIVolume, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	if _, ok := f.cs.volumes[volume.Name]; !ok {
+		return nil, notFound("directcsivolumes", volume.Name)
+	}
+	f.cs.volumes[volume.Name] = volume.DeepCopy()
+	return volume.DeepCopy(), nil
+}
+
+func (f fakeVolumes) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	if _, ok := f.cs.volumes[name]; !ok {
+		return notFound("directcsivolumes", name)
+	}
+	delete(f.cs.volumes, name)
+	return nil
+}
+
+// fakeV1beta1 downconverts the v1beta2 store to the legacy shape. Get and
+// List read through it; Update copies only DriveStatus and AccessTier back
+// onto the stored v1beta2 drive.
+type fakeV1beta1 struct{ cs *Clientset }
+
+func (f *fakeV1beta1) DirectCSIDrives() clientv1beta1.DirectCSIDriveInterface { return fakeDrivesV1beta1{f.cs} }
+
+type fakeDrivesV1beta1 struct{ cs *Clientset }
+
+func downconvert(d *directcsiv1beta2.DirectCSIDrive) *directcsiv1beta1.DirectCSIDrive {
+	return &directcsiv1beta1.DirectCSIDrive{
+		ObjectMeta: d.ObjectMeta,
+		Status: directcsiv1beta1.DirectCSIDriveStatus{
+			NodeName:    d.Status.NodeName,
+			Path:        d.Status.Path,
+			DriveStatus: directcsiv1beta1.DriveStatus(d.Status.DriveStatus),
+			AccessTier:  directcsiv1beta1.AccessTier(d.Status.AccessTier),
+		},
+	}
+}
+
+func (f fakeDrivesV1beta1) Get(_ context.Context, name string, _ metav1.GetOptions) (*directcsiv1beta1.DirectCSIDrive, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	d, ok := f.cs.drives[name]
+	if !ok {
+		return nil, notFound("directcsidrives", name)
+	}
+	return downconvert(d), nil
+}
+
+func (f fakeDrivesV1beta1) List(_ context.Context, _ metav1.ListOptions) (*directcsiv1beta1.DirectCSIDriveList, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	list := &directcsiv1beta1.DirectCSIDriveList{}
+	for _, d := range f.cs.drives {
+		list.Items = append(list.Items, *downconvert(d))
+	}
+	return list, nil
+}
+
+func (f fakeDrivesV1beta1) Update(_ context.Context, drive *directcsiv1beta1.DirectCSIDrive, _ metav1.UpdateOptions) (*directcsiv1beta1.DirectCSIDrive, error) {
+	f.cs.mu.Lock()
+	defer f.cs.mu.Unlock()
+	existing, ok := f.cs.drives[drive.Name]
+	if !ok {
+		return nil, notFound("directcsidrives", drive.Name)
+	}
+	existing.Status.DriveStatus = directcsiv1beta2.DriveStatus(drive.Status.DriveStatus)
+	existing.Status.AccessTier = directcsiv1beta2.AccessTier(drive.Status.AccessTier)
+	return downconvert(existing), nil
+}
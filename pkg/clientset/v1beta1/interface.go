@@ -0,0 +1,39 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package v1beta1 declares the typed client interface for the legacy
+// v1beta1 DirectCSIDrive resource.
+package v1beta1
+
+import (
+	"context"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DirectV1beta1Interface exposes the v1beta1 resources.
+type DirectV1beta1Interface interface {
+	DirectCSIDrives() DirectCSIDriveInterface
+}
+
+// DirectCSIDriveInterface is the typed client for the legacy DirectCSIDrive.
+type DirectCSIDriveInterface interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*directcsi.DirectCSIDrive, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*directcsi.DirectCSIDriveList, error)
+	Update(ctx context.Context, drive *directcsi.DirectCSIDrive, opts metav1.UpdateOptions) (*directcsi.DirectCSIDrive, error)
+}
@@ -0,0 +1,53 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package v1beta2 declares the typed client interfaces for the v1beta2
+// direct.csi.min.io resources. It plays the role that client-gen would
+// normally fill; hand-written here since this repo slice has no generator
+// wired up yet.
+package v1beta2
+
+import (
+	"context"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DirectV1beta2Interface exposes the v1beta2 resources.
+type DirectV1beta2Interface interface {
+	DirectCSIDrives() DirectCSIDriveInterface
+	DirectCSIVolumes() DirectCSIVolumeInterface
+}
+
+// DirectCSIDriveInterface is the typed client for DirectCSIDrive.
+type DirectCSIDriveInterface interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*directcsi.DirectCSIDrive, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*directcsi.DirectCSIDriveList, error)
+	Create(ctx context.Context, drive *directcsi.DirectCSIDrive, opts metav1.CreateOptions) (*directcsi.DirectCSIDrive, error)
+	Update(ctx context.Context, drive *directcsi.DirectCSIDrive, opts metav1.UpdateOptions) (*directcsi.DirectCSIDrive, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// DirectCSIVolumeInterface is the typed client for DirectCSIVolume.
+type DirectCSIVolumeInterface interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*directcsi.DirectCSIVolume, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*directcsi.DirectCSIVolumeList, error)
+	Create(ctx context.Context, volume *directcsi.DirectCSIVolume, opts metav1.CreateOptions) (*directcsi.DirectCSIVolume, error)
+	Update(ctx context.Context, volume *directcsi.DirectCSIVolume, opts metav1.UpdateOptions) (*directcsi.DirectCSIVolume, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
@@ -0,0 +1,93 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// VolumeClaimIDParameter is the StorageClass parameter that groups PVCs which
+// must never be scheduled onto the same DirectCSIDrive, e.g. the erasure-coded
+// volumes of a single MinIO StatefulSet pod spread across the disks of a node.
+const VolumeClaimIDParameter = "direct.csi.min.io/volume-claim-id"
+
+// claimIDLabel is the key under which the claim-id is recorded on the
+// provisioned DirectCSIVolume.
+const claimIDLabel = "direct.csi.min.io/volume-claim-id"
+
+// claimIDLabelPrefix is the prefix under which every claim-id currently
+// allocated on a drive is recorded in the drive's Labels set, one label per
+// claim-id (direct.csi.min.io/volume-claim-id.<claimID>), so that a drive can
+// hold volumes for more than one claim-id without later reservations
+// clobbering earlier ones.
+const claimIDLabelPrefix = "direct.csi.min.io/volume-claim-id."
+
+// excludeDrivesWithClaimID removes any drive that already hosts a volume
+// carrying the given claim-id from the list of scheduling candidates.
+func excludeDrivesWithClaimID(drives []directcsi.DirectCSIDrive, claimID string) []directcsi.DirectCSIDrive {
+	if claimID == "" {
+		return drives
+	}
+	filtered := make([]directcsi.DirectCSIDrive, 0, len(drives))
+	for _, d := range drives {
+		if _, ok := d.Status.Labels[claimIDLabelPrefix+claimID]; ok {
+			continue
+		}
+		filtered = append(filtered, d)
+	}
+	return filtered
+}
+
+// reserveClaimID adds claimID to the drive's set of allocated claim-ids
+// under retry.RetryOnConflict, without disturbing any other claim-id already
+// reserved on the same drive. It does not change the drive's
+// AllocatedCapacity or FreeCapacity.
+func (c *ControllerServer) reserveClaimID(ctx context.Context, driveName, claimID string) error {
+	if claimID == "" {
+		return nil
+	}
+
+	directCSIClient := c.directcsiClient.DirectV1beta2()
+	driveClient := directCSIClient.DirectCSIDrives()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drive, err := driveClient.Get(ctx, driveName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if drive.Status.Labels == nil {
+			drive.Status.Labels = map[string]string{}
+		}
+		drive.Status.Labels[claimIDLabelPrefix+claimID] = claimID
+		_, err = driveClient.Update(ctx, drive, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// volumeClaimIDLabels returns the label set to stamp onto a newly provisioned
+// DirectCSIVolume so future scheduling decisions can find its claim-id.
+func volumeClaimIDLabels(claimID string) map[string]string {
+	if claimID == "" {
+		return nil
+	}
+	return map[string]string{claimIDLabel: claimID}
+}
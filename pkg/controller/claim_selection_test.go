@@ -0,0 +1,104 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	"github.com/minio/direct-csi/pkg/clientset/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func driveWithClaimID(name, claimID string) directcsi.DirectCSIDrive {
+	drive := directcsi.DirectCSIDrive{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if claimID != "" {
+		drive.Status.Labels = map[string]string{claimIDLabelPrefix + claimID: claimID}
+	}
+	return drive
+}
+
+func TestExcludeDrivesWithClaimID(t *testing.T) {
+	drives := []directcsi.DirectCSIDrive{
+		driveWithClaimID("drive-a", "pod-0"),
+		driveWithClaimID("drive-b", ""),
+		driveWithClaimID("drive-c", "pod-1"),
+	}
+
+	filtered := excludeDrivesWithClaimID(drives, "pod-0")
+	if len(filtered) != 2 {
+		t.Fatalf("expected 2 drives to remain, got %d: %v", len(filtered), filtered)
+	}
+	for _, d := range filtered {
+		if d.Name == "drive-a" {
+			t.Fatalf("drive-a already hosts claim-id pod-0 and should have been excluded")
+		}
+	}
+}
+
+func TestExcludeDrivesWithClaimIDEmptyClaimIsNoOp(t *testing.T) {
+	drives := []directcsi.DirectCSIDrive{
+		driveWithClaimID("drive-a", "pod-0"),
+		driveWithClaimID("drive-b", ""),
+	}
+
+	filtered := excludeDrivesWithClaimID(drives, "")
+	if len(filtered) != len(drives) {
+		t.Fatalf("expected all %d drives to remain for an empty claim-id, got %d", len(drives), len(filtered))
+	}
+}
+
+func TestVolumeClaimIDLabels(t *testing.T) {
+	if labels := volumeClaimIDLabels(""); labels != nil {
+		t.Fatalf("expected nil labels for an empty claim-id, got %v", labels)
+	}
+
+	labels := volumeClaimIDLabels("pod-0")
+	if labels[claimIDLabel] != "pod-0" {
+		t.Fatalf("expected claim-id label to be set, got %v", labels)
+	}
+}
+
+func TestReserveClaimIDDoesNotOverwriteOthers(t *testing.T) {
+	drive := &directcsi.DirectCSIDrive{ObjectMeta: metav1.ObjectMeta{Name: "drive-a"}}
+	clientset := fake.NewSimpleClientset(drive)
+	controller := &ControllerServer{directcsiClient: clientset}
+	ctx := context.Background()
+
+	if err := controller.reserveClaimID(ctx, "drive-a", "pod-0"); err != nil {
+		t.Fatalf("reserving pod-0: %v", err)
+	}
+	if err := controller.reserveClaimID(ctx, "drive-a", "pod-1"); err != nil {
+		t.Fatalf("reserving pod-1: %v", err)
+	}
+
+	updated, err := clientset.DirectV1beta2().DirectCSIDrives().Get(ctx, "drive-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting drive-a: %v", err)
+	}
+
+	excluded := excludeDrivesWithClaimID([]directcsi.DirectCSIDrive{*updated}, "pod-0")
+	if len(excluded) != 0 {
+		t.Fatalf("expected drive-a to still be excluded for pod-0 after reserving pod-1, got %v", excluded)
+	}
+	excluded = excludeDrivesWithClaimID([]directcsi.DirectCSIDrive{*updated}, "pod-1")
+	if len(excluded) != 0 {
+		t.Fatalf("expected drive-a to be excluded for pod-1, got %v", excluded)
+	}
+}
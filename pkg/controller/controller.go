@@ -0,0 +1,203 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package controller implements the CSI Identity and Controller services,
+// provisioning DirectCSIVolume objects against Ready DirectCSIDrives.
+package controller
+
+import (
+	"context"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	"github.com/minio/direct-csi/pkg/clientset"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControllerServer implements csi.IdentityServer and csi.ControllerServer
+// against a clientset.Interface, so it can be driven by either the real
+// REST-backed clientset or the fake, in-memory one used in tests.
+type ControllerServer struct {
+	csi.UnimplementedIdentityServer
+	csi.UnimplementedControllerServer
+
+	identity        string
+	directcsiClient clientset.Interface
+}
+
+// NewControllerServer returns a ControllerServer identifying itself as
+// identity, backed by directcsiClient.
+func NewControllerServer(identity string, directcsiClient clientset.Interface) *ControllerServer {
+	return &ControllerServer{
+		identity:        identity,
+		directcsiClient: directcsiClient,
+	}
+}
+
+// GetPluginInfo implements csi.IdentityServer.
+func (c *ControllerServer) GetPluginInfo(ctx context.Context, req *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
+	return &csi.GetPluginInfoResponse{
+		Name:          c.identity,
+		VendorVersion: "edge",
+	}, nil
+}
+
+// GetPluginCapabilities implements csi.IdentityServer.
+func (c *ControllerServer) GetPluginCapabilities(ctx context.Context, req *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
+	return &csi.GetPluginCapabilitiesResponse{
+		Capabilities: []*csi.PluginCapability{
+			{
+				Type: &csi.PluginCapability_Service_{
+					Service: &csi.PluginCapability_Service{
+						Type: csi.PluginCapability_Service_CONTROLLER_SERVICE,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Probe implements csi.IdentityServer.
+func (c *ControllerServer) Probe(ctx context.Context, req *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+	return &csi.ProbeResponse{}, nil
+}
+
+// ControllerGetCapabilities implements csi.ControllerServer.
+func (c *ControllerServer) ControllerGetCapabilities(ctx context.Context, req *csi.ControllerGetCapabilitiesRequest) (*csi.ControllerGetCapabilitiesResponse, error) {
+	return &csi.ControllerGetCapabilitiesResponse{
+		Capabilities: []*csi.ControllerServiceCapability{
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// CreateVolume picks a Ready drive with enough free capacity for the
+// request, honouring the volume-claim-id anti-affinity parameter, and
+// provisions a DirectCSIVolume on it.
+//
+// Drive selection excludes any drive already hosting a volume for the same
+// claim-id (excludeDrivesWithClaimID) so that, e.g., the erasure-coded
+// volumes of one MinIO pod land on distinct drives. Once a drive is picked,
+// the claim-id is reserved against it (reserveClaimID) and stamped onto the
+// new DirectCSIVolume (volumeClaimIDLabels) so later CreateVolume calls can
+// find it again.
+func (c *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	sizeBytes := uint64(0)
+	if cr := req.GetCapacityRange(); cr != nil {
+		sizeBytes = uint64(cr.GetRequiredBytes())
+	}
+
+	claimID := req.GetParameters()[VolumeClaimIDParameter]
+
+	directCSIClient := c.directcsiClient.DirectV1beta2()
+
+	// Idempotency: a volume of this name may already have been provisioned
+	// by a prior, retried CreateVolume call.
+	if existing, err := directCSIClient.DirectCSIVolumes().Get(ctx, name, metav1.GetOptions{}); err == nil {
+		return &csi.CreateVolumeResponse{
+			Volume: &csi.Volume{
+				VolumeId:      existing.Name,
+				CapacityBytes: int64(existing.Status.TotalCapacity),
+			},
+		}, nil
+	}
+
+	driveList, err := directCSIClient.DirectCSIDrives().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing drives: %v", err)
+	}
+
+	candidates := excludeDrivesWithClaimID(driveList.Items, claimID)
+
+	var selected *directcsi.DirectCSIDrive
+	for i := range candidates {
+		drive := candidates[i]
+		if drive.Status.Cordoned {
+			continue
+		}
+		if drive.Status.DriveStatus != directcsi.DriveStatusReady {
+			continue
+		}
+		if drive.Status.FreeCapacity < sizeBytes {
+			continue
+		}
+		selected = &drive
+		break
+	}
+	if selected == nil {
+		return nil, status.Errorf(codes.ResourceExhausted, "no drive with %d free bytes available for claim-id %q", sizeBytes, claimID)
+	}
+
+	if err := c.reserveClaimID(ctx, selected.Name, claimID); err != nil {
+		return nil, status.Errorf(codes.Internal, "reserving claim-id on drive %s: %v", selected.Name, err)
+	}
+
+	volume := &directcsi.DirectCSIVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: volumeClaimIDLabels(claimID),
+		},
+		Status: directcsi.DirectCSIVolumeStatus{
+			Drive:         selected.Name,
+			NodeName:      selected.Status.NodeName,
+			TotalCapacity: sizeBytes,
+		},
+	}
+	if _, err := directCSIClient.DirectCSIVolumes().Create(ctx, volume, metav1.CreateOptions{}); err != nil {
+		return nil, status.Errorf(codes.Internal, "creating volume %s: %v", name, err)
+	}
+
+	return &csi.CreateVolumeResponse{
+		Volume: &csi.Volume{
+			VolumeId:      name,
+			CapacityBytes: int64(sizeBytes),
+			VolumeContext: map[string]string{
+				"drive": selected.Name,
+			},
+		},
+	}, nil
+}
+
+// DeleteVolume removes the DirectCSIVolume object. It does not clear the
+// owning drive's claim-id reservation, so that drive stays excluded from
+// later CreateVolume calls with the same claim-id.
+func (c *ControllerServer) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+	volumeID := req.GetVolumeId()
+	if volumeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume_id is required")
+	}
+
+	volumeClient := c.directcsiClient.DirectV1beta2().DirectCSIVolumes()
+	if err := volumeClient.Delete(ctx, volumeID, metav1.DeleteOptions{}); err != nil {
+		return nil, status.Errorf(codes.Internal, "deleting volume %s: %v", volumeID, err)
+	}
+
+	return &csi.DeleteVolumeResponse{}, nil
+}
@@ -0,0 +1,49 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package installer
+
+import corev1 "k8s.io/api/core/v1"
+
+// Args holds every value the manifest builders need. It is populated from
+// the same viper flags consumed by `cmd/direct-csi`'s driverCmd so that a
+// declaratively generated manifest always matches what `install` would have
+// applied live.
+type Args struct {
+	Identity string
+	Image    string
+
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+
+	Rack   string
+	Zone   string
+	Region string
+
+	WithPSP bool
+}
+
+// DefaultArgs returns an Args populated with the same defaults used by the
+// `install` command.
+func DefaultArgs() *Args {
+	return &Args{
+		Identity: "direct.csi.min.io",
+		Image:    "direct-csi",
+		Rack:     "default",
+		Zone:     "default",
+		Region:   "default",
+	}
+}
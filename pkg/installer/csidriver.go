@@ -0,0 +1,45 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package installer
+
+import (
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CSIDriver builds the CSIDriver object that registers this driver's
+// identity with kubelet.
+func CSIDriver(args *Args) *storagev1.CSIDriver {
+	podInfoOnMount := true
+	attachRequired := false
+	return &storagev1.CSIDriver{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "storage.k8s.io/v1",
+			Kind:       "CSIDriver",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: args.Identity,
+		},
+		Spec: storagev1.CSIDriverSpec{
+			PodInfoOnMount: &podInfoOnMount,
+			AttachRequired: &attachRequired,
+			VolumeLifecycleModes: []storagev1.VolumeLifecycleMode{
+				storagev1.VolumeLifecyclePersistent,
+			},
+		},
+	}
+}
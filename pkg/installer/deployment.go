@@ -0,0 +1,69 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package installer
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Deployment builds the cluster-wide controller Deployment
+// (`--run-controller-service`).
+func Deployment(args *Args) *appsv1.Deployment {
+	labels := map[string]string{"app": args.Identity, "role": "controller"}
+	replicas := int32(2)
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      args.Identity + "-controller",
+			Namespace: args.Identity,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: args.Identity,
+					Containers: []corev1.Container{
+						driverContainer(args, "--run-controller-service"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// driverContainer builds the container shared by the DaemonSet and
+// Deployment, differing only in which run flags are passed.
+func driverContainer(args *Args, runFlags ...string) corev1.Container {
+	return corev1.Container{
+		Name:  "direct-csi",
+		Image: args.Image,
+		Args: append([]string{
+			"--identity=" + args.Identity,
+			"--rack=" + args.Rack,
+			"--zone=" + args.Zone,
+			"--region=" + args.Region,
+		}, runFlags...),
+	}
+}
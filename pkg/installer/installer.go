@@ -0,0 +1,75 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package installer builds the Kubernetes manifests direct-csi needs
+// (Namespace, CRDs, RBAC, CSIDriver, StorageClass, DaemonSet, Deployment)
+// as a composable set of typed builders, one per resource kind. It backs
+// both the declarative `install --declarative -o yaml` mode, which streams
+// the manifest set to stdout instead of applying it via the dynamic client,
+// and the `migrate` command, which diffs the generated manifest against a
+// live cluster.
+package installer
+
+import (
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// Manifests returns every object that makes up a direct-csi install, in
+// apply order (namespace and CRDs first, then RBAC, CSIDriver, StorageClass
+// and the workloads; the PodSecurityPolicy, when withPSP is set, comes last).
+func Manifests(args *Args, withPSP bool) []runtime.Object {
+	objects := []runtime.Object{
+		Namespace(args),
+	}
+	for _, crd := range CRDs(args) {
+		objects = append(objects, crd)
+	}
+	objects = append(objects,
+		ServiceAccount(args),
+		ClusterRole(args, withPSP),
+		ClusterRoleBinding(args),
+		CSIDriver(args),
+		StorageClass(args),
+		DaemonSet(args),
+		Deployment(args),
+	)
+	if withPSP {
+		objects = append(objects, PodSecurityPolicy(args))
+	}
+	return objects
+}
+
+// WriteYAML streams every object in Manifests as a single multi-document
+// YAML stream, the format consumed by `kubectl apply -f -`. `migrate` does
+// not read this stream; it builds the same objects through Manifests.
+func WriteYAML(w io.Writer, args *Args) error {
+	serializer := json.NewYAMLSerializer(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme)
+	for i, obj := range Manifests(args, args.WithPSP) {
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if err := serializer.Encode(obj, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package installer
+
+import (
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodSecurityPolicy builds the PodSecurityPolicy direct-csi's DaemonSet
+// needs to run privileged, host-mounting pods on clusters that enforce PSP
+// admission. It is only added to Manifests when `--psp` is passed, since
+// most clusters this driver targets have PSP disabled or removed.
+func PodSecurityPolicy(args *Args) *policyv1beta1.PodSecurityPolicy {
+	allowedHostPaths := []policyv1beta1.AllowedHostPath{
+		{PathPrefix: "/dev", ReadOnly: false},
+		{PathPrefix: "/var/lib/direct-csi", ReadOnly: false},
+	}
+
+	return &policyv1beta1.PodSecurityPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1beta1",
+			Kind:       "PodSecurityPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: args.Identity,
+		},
+		Spec: policyv1beta1.PodSecurityPolicySpec{
+			Privileged:               true,
+			AllowPrivilegeEscalation: boolPtr(true),
+			AllowedHostPaths:         allowedHostPaths,
+			Volumes: []policyv1beta1.FSType{
+				policyv1beta1.HostPath,
+				policyv1beta1.Secret,
+			},
+			HostNetwork: false,
+			HostIPC:     false,
+			HostPID:     true,
+			RunAsUser: policyv1beta1.RunAsUserStrategyOptions{
+				Rule: policyv1beta1.RunAsUserStrategyRunAsAny,
+			},
+			SELinux: policyv1beta1.SELinuxStrategyOptions{
+				Rule: policyv1beta1.SELinuxStrategyRunAsAny,
+			},
+			SupplementalGroups: policyv1beta1.SupplementalGroupsStrategyOptions{
+				Rule: policyv1beta1.SupplementalGroupsStrategyRunAsAny,
+			},
+			FSGroup: policyv1beta1.FSGroupStrategyOptions{
+				Rule: policyv1beta1.FSGroupStrategyRunAsAny,
+			},
+		},
+	}
+}
@@ -0,0 +1,106 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package installer
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceAccount builds the ServiceAccount the driver's pods run as.
+func ServiceAccount(args *Args) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      args.Identity,
+			Namespace: args.Identity,
+		},
+	}
+}
+
+// ClusterRole builds the ClusterRole granting access to drives, volumes,
+// persistent volumes and the other resources direct-csi manages. When
+// withPSP is set, it also grants 'use' on the PodSecurityPolicy built by
+// PodSecurityPolicy, without which the driver's privileged, host-mounting
+// pods couldn't be admitted on a cluster that enforces PSP.
+func ClusterRole(args *Args, withPSP bool) *rbacv1.ClusterRole {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"direct.csi.min.io"},
+			Resources: []string{"directcsidrives", "directcsidrives/status", "directcsivolumes", "directcsivolumes/status"},
+			Verbs:     []string{"get", "list", "watch", "update", "create", "delete"},
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"persistentvolumes", "persistentvolumeclaims", "events", "nodes"},
+			Verbs:     []string{"get", "list", "watch", "update", "create"},
+		},
+		{
+			APIGroups: []string{"storage.k8s.io"},
+			Resources: []string{"storageclasses", "csinodes", "volumeattachments"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+	if withPSP {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups:     []string{"policy"},
+			Resources:     []string{"podsecuritypolicies"},
+			ResourceNames: []string{args.Identity},
+			Verbs:         []string{"use"},
+		})
+	}
+
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: args.Identity,
+		},
+		Rules: rules,
+	}
+}
+
+// ClusterRoleBinding binds ClusterRole to ServiceAccount.
+func ClusterRoleBinding(args *Args) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: args.Identity,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     args.Identity,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      args.Identity,
+				Namespace: args.Identity,
+			},
+		},
+	}
+}
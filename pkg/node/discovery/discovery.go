@@ -0,0 +1,73 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package discovery
+
+import (
+	"context"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	"github.com/minio/direct-csi/pkg/clientset"
+	"github.com/minio/direct-csi/pkg/sys"
+)
+
+// Discovery syncs the state of this node's drives against their
+// DirectCSIDrive objects; it does not enumerate the host's block devices
+// itself. Its driveMounter is injectable so tests can drive
+// verifyDriveMount with a fake mounter instead of shelling out to mount(8).
+type Discovery struct {
+	directcsiClient clientset.Interface
+	driveMounter    sys.DriveMounter
+	mounts          []sys.MountInfo
+}
+
+// NewDiscovery returns a Discovery backed by directcsiClient, mounting
+// drives through sys.DefaultDriveMounter.
+func NewDiscovery(directcsiClient clientset.Interface) *Discovery {
+	return NewDiscoveryWithMounter(directcsiClient, &sys.DefaultDriveMounter{})
+}
+
+// NewDiscoveryWithMounter returns a Discovery backed by directcsiClient,
+// mounting drives through the given driveMounter. Tests use this to inject
+// a fake mounter and run without root privileges or real block devices.
+func NewDiscoveryWithMounter(directcsiClient clientset.Interface, driveMounter sys.DriveMounter) *Discovery {
+	return &Discovery{
+		directcsiClient: directcsiClient,
+		driveMounter:    driveMounter,
+	}
+}
+
+// Sync reconciles one locally-discovered drive against its DirectCSIDrive
+// object, mounting it through the injected driveMounter if needed. This is
+// the entrypoint the discovery loop (and the csi-sanity harness) calls per
+// scan cycle.
+func (d *Discovery) Sync(ctx context.Context, localDrive *directcsi.DirectCSIDrive) error {
+	if err := d.refreshMounts(); err != nil {
+		return err
+	}
+	return d.syncDrive(ctx, localDrive, noOpSyncFn)
+}
+
+// refreshMounts reloads the host's mount table that verifyDriveMount
+// consults to decide whether a drive still needs mounting.
+func (d *Discovery) refreshMounts() error {
+	mounts, err := sys.ReadMounts()
+	if err != nil {
+		return err
+	}
+	d.mounts = mounts
+	return nil
+}
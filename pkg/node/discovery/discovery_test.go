@@ -0,0 +1,97 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package discovery
+
+import (
+	"testing"
+
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	"github.com/minio/direct-csi/pkg/sys"
+)
+
+// fakeMounter records MountDrive calls instead of shelling out to mount(8),
+// so verifyDriveMount can be exercised without root privileges.
+type fakeMounter struct {
+	mounted map[string]string // source -> target
+}
+
+func newFakeMounter() *fakeMounter {
+	return &fakeMounter{mounted: map[string]string{}}
+}
+
+func (f *fakeMounter) MountDrive(source, target string, flags []string) error {
+	f.mounted[source] = target
+	return nil
+}
+
+func (f *fakeMounter) UnmountDrive(target string) error {
+	for source, t := range f.mounted {
+		if t == target {
+			delete(f.mounted, source)
+		}
+	}
+	return nil
+}
+
+func (f *fakeMounter) FormatDrive(path, filesystem string) error { return nil }
+
+func TestVerifyDriveMountUsesInjectedMounter(t *testing.T) {
+	mounter := newFakeMounter()
+	d := NewDiscoveryWithMounter(nil, mounter)
+
+	drive := &directcsi.DirectCSIDrive{
+		Status: directcsi.DirectCSIDriveStatus{
+			DriveStatus:    directcsi.DriveStatusReady,
+			FilesystemUUID: "11111111-2222-3333-4444-555555555555",
+		},
+	}
+
+	if err := d.verifyDriveMount(drive); err != nil {
+		t.Fatalf("verifyDriveMount: %v", err)
+	}
+
+	source := sys.GetDirectCSIPath(drive.Status.FilesystemUUID)
+	if _, ok := mounter.mounted[source]; !ok {
+		t.Fatalf("expected %s to be mounted via the injected mounter, got %v", source, mounter.mounted)
+	}
+	if drive.Status.Mountpoint == "" {
+		t.Fatal("expected Status.Mountpoint to be set after mounting")
+	}
+}
+
+func TestVerifyDriveMountSkipsAlreadyMounted(t *testing.T) {
+	mounter := newFakeMounter()
+	d := NewDiscoveryWithMounter(nil, mounter)
+
+	source := sys.GetDirectCSIPath("already-mounted-uuid")
+	d.mounts = []sys.MountInfo{{MountSource: source, MountPoint: "/var/lib/direct-csi/mnt/already-mounted-uuid"}}
+
+	drive := &directcsi.DirectCSIDrive{
+		Status: directcsi.DirectCSIDriveStatus{
+			DriveStatus:    directcsi.DriveStatusReady,
+			FilesystemUUID: "already-mounted-uuid",
+		},
+	}
+
+	if err := d.verifyDriveMount(drive); err != nil {
+		t.Fatalf("verifyDriveMount: %v", err)
+	}
+
+	if len(mounter.mounted) != 0 {
+		t.Fatalf("expected no new mount for an already-mounted drive, got %v", mounter.mounted)
+	}
+}
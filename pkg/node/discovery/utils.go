@@ -39,14 +39,27 @@ var (
 )
 
 func (d *Discovery) verifyDriveMount(existingDrive *directcsi.DirectCSIDrive) error {
-	driveMounter := &sys.DefaultDriveMounter{}
+	return VerifyDriveMount(d.driveMounter, d.mounts, existingDrive)
+}
+
+// VerifyDriveMount mounts existingDrive at its canonical direct-csi mount
+// path if it isn't already mounted there, using mounter and the caller's
+// current view of the host's mount table (mounts). It is exported standalone
+// so callers outside the discovery loop - e.g. `drives move`, which must
+// remount a drive under its own FilesystemUUID immediately after an
+// ownership transfer rather than waiting for the next discovery cycle - can
+// drive the same mount logic discovery uses.
+func VerifyDriveMount(mounter sys.DriveMounter, mounts []sys.MountInfo, existingDrive *directcsi.DirectCSIDrive) error {
 	switch existingDrive.Status.DriveStatus {
 	case directcsi.DriveStatusInUse, directcsi.DriveStatusReady:
+		// Drives transitioned to InUse by a "drives move" ownership transfer
+		// are remounted here using their own FilesystemUUID, not the source
+		// drive's, since no data is copied across during the move.
 		mountSource := sys.GetDirectCSIPath(existingDrive.Status.FilesystemUUID)
 		mountTarget := filepath.Join(sys.MountRoot, existingDrive.Status.FilesystemUUID)
 		// Check if the drive is mounted
 		isMounted := false
-		for _, mount := range d.mounts {
+		for _, mount := range mounts {
 			if mount.MountSource == mountSource {
 				isMounted = true
 				break
@@ -54,7 +67,7 @@ func (d *Discovery) verifyDriveMount(existingDrive *directcsi.DirectCSIDrive) er
 		}
 		// Mount if umounted
 		if !isMounted {
-			if err := driveMounter.MountDrive(mountSource, mountTarget, []string{}); err != nil {
+			if err := mounter.MountDrive(mountSource, mountTarget, []string{}); err != nil {
 				return err
 			}
 			existingDrive.Status.Mountpoint = mountTarget
@@ -107,6 +120,8 @@ func syncDriveStatesOnDiscovery(existingObj *directcsi.DirectCSIDrive, localDriv
 	existingObj.Status.MajorNumber = localDrive.Status.MajorNumber
 	existingObj.Status.MinorNumber = localDrive.Status.MinorNumber
 	existingObj.Status.TotalCapacity = localDrive.Status.TotalCapacity
+	// Cordoned and Labels (volume-claim-id allocations) are controller-managed
+	// and must not be clobbered by discovery
 	// Capacity sync
 	allocatedCapacity := localDrive.Status.AllocatedCapacity
 	if existingObj.Status.DriveStatus == directcsi.DriveStatusInUse {
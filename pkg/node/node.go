@@ -0,0 +1,159 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package node implements the CSI Identity and Node services, staging and
+// publishing volumes onto the paths direct-csi discovery already mounted
+// their owning drives at.
+package node
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/minio/direct-csi/pkg/sys"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NodeServer implements csi.IdentityServer and csi.NodeServer against an
+// injected sys.DriveMounter, so it can be exercised by the csi-sanity suite
+// with a fake mounter instead of real block devices.
+type NodeServer struct {
+	csi.UnimplementedIdentityServer
+	csi.UnimplementedNodeServer
+
+	identity string
+	nodeID   string
+	mounter  sys.DriveMounter
+}
+
+// NewNodeServerWithMounter returns a NodeServer identifying itself as
+// identity/nodeID, driving mount/unmount operations through mounter.
+func NewNodeServerWithMounter(identity, nodeID string, mounter sys.DriveMounter) *NodeServer {
+	return &NodeServer{
+		identity: identity,
+		nodeID:   nodeID,
+		mounter:  mounter,
+	}
+}
+
+// GetPluginInfo implements csi.IdentityServer.
+func (n *NodeServer) GetPluginInfo(ctx context.Context, req *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
+	return &csi.GetPluginInfoResponse{
+		Name:          n.identity,
+		VendorVersion: "edge",
+	}, nil
+}
+
+// GetPluginCapabilities implements csi.IdentityServer.
+func (n *NodeServer) GetPluginCapabilities(ctx context.Context, req *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
+	return &csi.GetPluginCapabilitiesResponse{
+		Capabilities: []*csi.PluginCapability{
+			{
+				Type: &csi.PluginCapability_Service_{
+					Service: &csi.PluginCapability_Service{
+						Type: csi.PluginCapability_Service_VOLUME_ACCESSIBILITY_CONSTRAINTS,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// Probe implements csi.IdentityServer.
+func (n *NodeServer) Probe(ctx context.Context, req *csi.ProbeRequest) (*csi.ProbeResponse, error) {
+	return &csi.ProbeResponse{}, nil
+}
+
+// NodeGetInfo implements csi.NodeServer.
+func (n *NodeServer) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
+	return &csi.NodeGetInfoResponse{NodeId: n.nodeID}, nil
+}
+
+// NodeGetCapabilities implements csi.NodeServer.
+func (n *NodeServer) NodeGetCapabilities(ctx context.Context, req *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
+	return &csi.NodeGetCapabilitiesResponse{
+		Capabilities: []*csi.NodeServiceCapability{
+			{
+				Type: &csi.NodeServiceCapability_Rpc{
+					Rpc: &csi.NodeServiceCapability_RPC{
+						Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// NodeStageVolume bind-mounts the volume's drive at the staging path, so
+// NodePublishVolume only needs a bind-mount from there to the target path.
+func (n *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolumeRequest) (*csi.NodeStageVolumeResponse, error) {
+	volumeID := req.GetVolumeId()
+	stagingPath := req.GetStagingTargetPath()
+	if volumeID == "" || stagingPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume_id and staging_target_path are required")
+	}
+
+	source := filepath.Join(sys.MountRoot, volumeID)
+	if err := n.mounter.MountDrive(source, stagingPath, []string{"--bind"}); err != nil {
+		return nil, status.Errorf(codes.Internal, "staging volume %s: %v", volumeID, err)
+	}
+
+	return &csi.NodeStageVolumeResponse{}, nil
+}
+
+// NodeUnstageVolume implements csi.NodeServer.
+func (n *NodeServer) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstageVolumeRequest) (*csi.NodeUnstageVolumeResponse, error) {
+	if req.GetVolumeId() == "" || req.GetStagingTargetPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume_id and staging_target_path are required")
+	}
+
+	if err := n.mounter.UnmountDrive(req.GetStagingTargetPath()); err != nil {
+		return nil, status.Errorf(codes.Internal, "unstaging volume %s: %v", req.GetVolumeId(), err)
+	}
+
+	return &csi.NodeUnstageVolumeResponse{}, nil
+}
+
+// NodePublishVolume bind-mounts the staged volume at the target path.
+func (n *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
+	volumeID := req.GetVolumeId()
+	targetPath := req.GetTargetPath()
+	if volumeID == "" || targetPath == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume_id and target_path are required")
+	}
+
+	if err := n.mounter.MountDrive(req.GetStagingTargetPath(), targetPath, []string{"--bind"}); err != nil {
+		return nil, status.Errorf(codes.Internal, "publishing volume %s: %v", volumeID, err)
+	}
+
+	return &csi.NodePublishVolumeResponse{}, nil
+}
+
+// NodeUnpublishVolume implements csi.NodeServer.
+func (n *NodeServer) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublishVolumeRequest) (*csi.NodeUnpublishVolumeResponse, error) {
+	if req.GetVolumeId() == "" || req.GetTargetPath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume_id and target_path are required")
+	}
+
+	if err := n.mounter.UnmountDrive(req.GetTargetPath()); err != nil {
+		return nil, status.Errorf(codes.Internal, "unpublishing volume %s: %v", req.GetVolumeId(), err)
+	}
+
+	return &csi.NodeUnpublishVolumeResponse{}, nil
+}
@@ -0,0 +1,98 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package selectors parses and validates the glob selectors accepted by the
+// drives/volumes subcommands (--drives, --nodes, --access-tier, --status)
+// so that a malformed selector, e.g. "--status=raedy", is rejected before
+// any API call is made rather than silently matching zero resources.
+package selectors
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// knownStatuses mirrors the DriveStatus values defined on DirectCSIDrive.
+var knownStatuses = []string{"Ready", "InUse", "Available", "Unavailable", "Unidentified", "Terminating", "Released"}
+
+// knownAccessTiers mirrors the AccessTier values defined on DirectCSIDrive.
+var knownAccessTiers = []string{"Hot", "Warm", "Cold"}
+
+// GetValidDriveSelectors validates that every --drives value is a
+// syntactically valid glob pattern (e.g. "/dev/nvme*").
+func GetValidDriveSelectors(drives []string) ([]string, error) {
+	return validateGlobs("drives", drives)
+}
+
+// GetValidNodeSelectors validates that every --nodes value is a
+// syntactically valid glob pattern (e.g. "directcsi-*").
+func GetValidNodeSelectors(nodes []string) ([]string, error) {
+	return validateGlobs("nodes", nodes)
+}
+
+// GetValidAccessTierSelectors validates that every --access-tier value is a
+// glob pattern that matches at least one known access-tier (hot, warm, cold).
+func GetValidAccessTierSelectors(accessTiers []string) ([]string, error) {
+	return validateAgainstKnown("access-tier", accessTiers, knownAccessTiers)
+}
+
+// GetValidStatusSelectors validates that every --status value is a glob
+// pattern that matches at least one known drive status.
+func GetValidStatusSelectors(status []string) ([]string, error) {
+	return validateAgainstKnown("status", status, knownStatuses)
+}
+
+func validateGlobs(flagName string, values []string) ([]string, error) {
+	valid := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, fmt.Errorf("--%s: empty selector is not valid", flagName)
+		}
+		if _, err := filepath.Match(v, ""); err != nil {
+			return nil, fmt.Errorf("--%s: invalid glob pattern %q: %w", flagName, v, err)
+		}
+		valid = append(valid, v)
+	}
+	return valid, nil
+}
+
+func validateAgainstKnown(flagName string, values, known []string) ([]string, error) {
+	valid := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, fmt.Errorf("--%s: empty selector is not valid", flagName)
+		}
+		matched := false
+		for _, k := range known {
+			ok, err := filepath.Match(strings.ToLower(v), strings.ToLower(k))
+			if err != nil {
+				return nil, fmt.Errorf("--%s: invalid glob pattern %q: %w", flagName, v, err)
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("--%s: %q does not match any of the known values %s", flagName, v, strings.Join(known, ", "))
+		}
+		valid = append(valid, v)
+	}
+	return valid, nil
+}
@@ -0,0 +1,117 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package selectors
+
+import "testing"
+
+func TestGetValidDriveSelectors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		drives  []string
+		wantErr bool
+	}{
+		{"empty", []string{}, false},
+		{"single", []string{"/dev/nvme0n1"}, false},
+		{"glob", []string{"/dev/nvme*"}, false},
+		{"multi-arg", []string{"/dev/sda", "/dev/sdb"}, false},
+		{"csv-split-by-cobra", []string{"/dev/sda", "/dev/sdb", "/dev/sdc"}, false},
+		{"empty selector", []string{""}, true},
+		{"invalid glob", []string{"[unterminated"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := GetValidDriveSelectors(tc.drives)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetValidDriveSelectors(%v) error = %v, wantErr %v", tc.drives, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetValidNodeSelectors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		nodes   []string
+		wantErr bool
+	}{
+		{"empty", []string{}, false},
+		{"single", []string{"directcsi-1"}, false},
+		{"glob", []string{"directcsi-*"}, false},
+		{"multi-arg", []string{"directcsi-1", "directcsi-2"}, false},
+		{"empty selector", []string{""}, true},
+		{"invalid glob", []string{"directcsi-[1"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := GetValidNodeSelectors(tc.nodes)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetValidNodeSelectors(%v) error = %v, wantErr %v", tc.nodes, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetValidStatusSelectors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  []string
+		wantErr bool
+	}{
+		{"empty", []string{}, false},
+		{"exact", []string{"Ready"}, false},
+		{"case insensitive", []string{"ready"}, false},
+		{"glob prefix", []string{"Un*"}, false},
+		{"multi-arg", []string{"Ready", "InUse"}, false},
+		{"empty selector", []string{""}, true},
+		{"typo", []string{"raedy"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := GetValidStatusSelectors(tc.status)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetValidStatusSelectors(%v) error = %v, wantErr %v", tc.status, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetValidAccessTierSelectors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		accessTier []string
+		wantErr    bool
+	}{
+		{"empty", []string{}, false},
+		{"exact", []string{"Hot"}, false},
+		{"case insensitive", []string{"cold"}, false},
+		{"multi-arg", []string{"Hot", "Cold"}, false},
+		{"empty selector", []string{""}, true},
+		{"unknown tier", []string{"lukewarm"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := GetValidAccessTierSelectors(tc.accessTier)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetValidAccessTierSelectors(%v) error = %v, wantErr %v", tc.accessTier, err, tc.wantErr)
+			}
+		})
+	}
+}
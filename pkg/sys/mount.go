@@ -0,0 +1,74 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+)
+
+// MountRoot is the host path direct-csi mounts identified drives under.
+const MountRoot = "/var/lib/direct-csi/mnt"
+
+// DriveMounter abstracts the mount/format syscalls so callers (discovery,
+// the node service) can be driven by a fake implementation in tests without
+// root privileges or real block devices.
+type DriveMounter interface {
+	MountDrive(source, target string, flags []string) error
+	UnmountDrive(target string) error
+	FormatDrive(path, filesystem string) error
+}
+
+// DefaultDriveMounter shells out to the host's mount(8)/umount(8)/mkfs
+// utilities. It is the DriveMounter used outside of tests.
+type DefaultDriveMounter struct{}
+
+// MountDrive mounts source at target with the given mount flags.
+func (DefaultDriveMounter) MountDrive(source, target string, flags []string) error {
+	args := append([]string{}, flags...)
+	args = append(args, source, target)
+	out, err := exec.Command("mount", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("mount %s at %s: %w: %s", source, target, err, out)
+	}
+	return nil
+}
+
+// UnmountDrive unmounts target.
+func (DefaultDriveMounter) UnmountDrive(target string) error {
+	out, err := exec.Command("umount", target).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("umount %s: %w: %s", target, err, out)
+	}
+	return nil
+}
+
+// FormatDrive formats path with the given filesystem type.
+func (DefaultDriveMounter) FormatDrive(path, filesystem string) error {
+	out, err := exec.Command("mkfs."+filesystem, path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("mkfs.%s %s: %w: %s", filesystem, path, err, out)
+	}
+	return nil
+}
+
+// GetDirectCSIPath returns the stable by-uuid device path direct-csi mounts
+// a drive from, given its filesystem UUID.
+func GetDirectCSIPath(filesystemUUID string) string {
+	return filepath.Join("/dev/disk/by-uuid", filesystemUUID)
+}
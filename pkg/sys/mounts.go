@@ -0,0 +1,58 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sys
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MountInfo is one entry of the host's mount table.
+type MountInfo struct {
+	MountSource string
+	MountPoint  string
+}
+
+// procMounts is the standard Linux mount table path. A var so tests can
+// point it at a fixture file.
+var procMounts = "/proc/mounts"
+
+// ReadMounts returns the host's current mount table.
+func ReadMounts() ([]MountInfo, error) {
+	f, err := os.Open(procMounts)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", procMounts, err)
+	}
+	defer f.Close()
+
+	var mounts []MountInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		mounts = append(mounts, MountInfo{MountSource: fields[0], MountPoint: fields[1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", procMounts, err)
+	}
+
+	return mounts, nil
+}
@@ -0,0 +1,65 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build sanity
+// +build sanity
+
+package e2e
+
+import (
+	"sync"
+)
+
+// fakeDriveMounter is an in-memory stand-in for sys.DefaultDriveMounter so
+// the sanity suite can exercise NodeStageVolume/NodePublishVolume without
+// root privileges or real block devices.
+type fakeDriveMounter struct {
+	mu     sync.Mutex
+	mounts map[string]string // source -> target
+}
+
+func newFakeDriveMounter() *fakeDriveMounter {
+	return &fakeDriveMounter{mounts: map[string]string{}}
+}
+
+func (f *fakeDriveMounter) MountDrive(source, target string, flags []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mounts[source] = target
+	return nil
+}
+
+func (f *fakeDriveMounter) UnmountDrive(target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for source, t := range f.mounts {
+		if t == target {
+			delete(f.mounts, source)
+		}
+	}
+	return nil
+}
+
+func (f *fakeDriveMounter) FormatDrive(path, filesystem string) error {
+	return nil
+}
+
+func (f *fakeDriveMounter) IsMounted(source string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	target, ok := f.mounts[source]
+	return target, ok
+}
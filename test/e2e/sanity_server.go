@@ -0,0 +1,80 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build sanity
+// +build sanity
+
+package e2e
+
+import (
+	directcsi "github.com/minio/direct-csi/pkg/apis/direct.csi.min.io/v1beta2"
+	directcsifake "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/controller"
+	"github.com/minio/direct-csi/pkg/node"
+
+	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sanityServer bundles the Identity, Controller and Node gRPC services the
+// sanity suite expects to find behind a single endpoint.
+type sanityServer struct {
+	identityServer   csi.IdentityServer
+	controllerServer csi.ControllerServer
+	nodeServer       csi.NodeServer
+}
+
+// newSanityServer wires the controller and node servers against the fake
+// directcsi clientset and fake drive mounter, so the full CreateVolume ->
+// NodeStageVolume -> NodePublishVolume path runs without root or real disks.
+func newSanityServer(fakeClient *directcsifake.Clientset, mounter *fakeDriveMounter) *sanityServer {
+	identity := "sanity.direct.csi.min.io"
+	ctrl := controller.NewControllerServer(identity, fakeClient)
+	nodeSrv := node.NewNodeServerWithMounter(identity, "sanity-node", mounter)
+
+	return &sanityServer{
+		identityServer:   ctrl,
+		controllerServer: ctrl,
+		nodeServer:       nodeSrv,
+	}
+}
+
+func registerSanityServer(grpcServer *grpc.Server, srv *sanityServer) {
+	csi.RegisterIdentityServer(grpcServer, srv.identityServer)
+	csi.RegisterControllerServer(grpcServer, srv.controllerServer)
+	csi.RegisterNodeServer(grpcServer, srv.nodeServer)
+}
+
+// newSanityDrive returns a synthetic DirectCSIDrive in the Ready state,
+// larger than the sanity suite's default 10 GiB test volume, so the
+// controller has somewhere to provision volumes.
+func newSanityDrive(name string) *directcsi.DirectCSIDrive {
+	const hundredGiB = 100 * 1024 * 1024 * 1024
+	return &directcsi.DirectCSIDrive{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: directcsi.DirectCSIDriveStatus{
+			NodeName:       "sanity-node",
+			Path:           "/dev/" + name,
+			DriveStatus:    directcsi.DriveStatusReady,
+			FilesystemUUID: name + "-uuid",
+			TotalCapacity:  hundredGiB,
+			FreeCapacity:   hundredGiB,
+		},
+	}
+}
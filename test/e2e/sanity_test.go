@@ -0,0 +1,97 @@
+// This file is part of MinIO Direct CSI
+// Copyright (c) 2021 MinIO, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build sanity
+// +build sanity
+
+// Package e2e wires the kubernetes-csi csi-test sanity suite against this
+// driver's controller and node gRPC servers, backed by a fake mount layer
+// and a fake directcsi clientset. This closes the gap where refactors to
+// syncDriveStatesOnDiscovery and the CSI RPCs were previously only caught
+// by manual functests against a real cluster.
+package e2e
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubernetes-csi/csi-test/v3/pkg/sanity"
+	directcsifake "github.com/minio/direct-csi/pkg/clientset/fake"
+	"github.com/minio/direct-csi/pkg/node/discovery"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// seedDrives creates a handful of synthetic DirectCSIDrive CRs in the fake
+// clientset, large and ready enough for the sanity suite to provision
+// volumes against.
+func seedDrives(fakeClient *directcsifake.Clientset) error {
+	for _, name := range []string{"sanity-drive-0", "sanity-drive-1"} {
+		drive := newSanityDrive(name)
+		if _, err := fakeClient.DirectV1beta2().DirectCSIDrives().Create(context.Background(), drive, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestSanity(t *testing.T) {
+	tmpDir := t.TempDir()
+	endpoint := "unix://" + filepath.Join(tmpDir, "csi.sock")
+	os.Remove(filepath.Join(tmpDir, "csi.sock"))
+
+	fakeClient := directcsifake.NewSimpleClientset()
+	if err := seedDrives(fakeClient); err != nil {
+		t.Fatalf("seeding fake drives: %v", err)
+	}
+
+	mounter := newFakeDriveMounter()
+
+	// Run the drives through discovery sync once so verifyDriveMount's
+	// injected-mounter path is actually exercised before the sanity suite
+	// starts provisioning volumes against them.
+	disc := discovery.NewDiscoveryWithMounter(fakeClient, mounter)
+	driveList, err := fakeClient.DirectV1beta2().DirectCSIDrives().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("listing seeded drives: %v", err)
+	}
+	for i := range driveList.Items {
+		if err := disc.Sync(context.Background(), &driveList.Items[i]); err != nil {
+			t.Fatalf("syncing drive %s: %v", driveList.Items[i].Name, err)
+		}
+	}
+
+	srv := newSanityServer(fakeClient, mounter)
+
+	listener, err := net.Listen("unix", filepath.Join(tmpDir, "csi.sock"))
+	if err != nil {
+		t.Fatalf("listening on fake csi socket: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	registerSanityServer(grpcServer, srv)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	config := sanity.TestConfig{
+		Address:     endpoint,
+		TargetPath:  filepath.Join(tmpDir, "target"),
+		StagingPath: filepath.Join(tmpDir, "staging"),
+	}
+	sanity.Test(t, config)
+}